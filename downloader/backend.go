@@ -0,0 +1,205 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Backend downloads and probes a single URL. *Downloader already satisfies
+// this interface, so the yt-dlp wrapper is itself a Backend; Registry lets
+// other Backends (HTTPBackend, FTPBackend, or caller-supplied ones) handle
+// URLs yt-dlp shouldn't be invoked for.
+type Backend interface {
+	Download(ctx context.Context, url string, opts Options) (*Result, error)
+	Probe(ctx context.Context, url string, opts ProbeOptions) (*VideoInfo, error)
+}
+
+var errNoBackendForURL = errors.New("no backend registered for url")
+
+// directFileExtensions are file extensions that identify a URL as a direct,
+// single-file download rather than a video-site page yt-dlp needs to parse.
+// Only http(s) URLs matching one of these (or a host registered with
+// RegisterHost) are routed away from the yt-dlp fallback.
+var directFileExtensions = map[string]bool{
+	".mp4": true, ".mkv": true, ".webm": true, ".mov": true, ".avi": true,
+	".flv": true, ".m4a": true, ".mp3": true, ".wav": true, ".zip": true,
+}
+
+// Registry dispatches a URL to a Backend by, in order of precedence: file
+// extension, host pattern, then URL scheme, falling back to a default
+// Backend (typically the yt-dlp wrapper) when nothing more specific matches.
+// This lets http(s) stay routed to yt-dlp by default — the overwhelming
+// majority of yt-dlp-supported sites are https pages, not direct files —
+// while direct-file URLs and other hosts/schemes still dispatch natively.
+type Registry struct {
+	mu         sync.RWMutex
+	extensions map[string]Backend
+	hosts      []hostRule
+	schemes    map[string]Backend
+	fallback   Backend
+}
+
+type hostRule struct {
+	pattern string // exact host, or "*.example.com" to match example.com and its subdomains
+	backend Backend
+}
+
+// NewRegistry creates a Registry that dispatches to fallback when no
+// extension/host/scheme rule matches.
+func NewRegistry(fallback Backend) *Registry {
+	return &Registry{
+		extensions: make(map[string]Backend),
+		schemes:    make(map[string]Backend),
+		fallback:   fallback,
+	}
+}
+
+// RegisterScheme routes any URL with the given scheme (e.g. "ftp") to
+// backend, unless a more specific extension or host rule matches first.
+// Scheme matching is case-insensitive.
+func (r *Registry) RegisterScheme(scheme string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schemes[strings.ToLower(scheme)] = backend
+}
+
+// RegisterHost routes http(s) URLs whose host matches pattern to backend.
+// pattern is either an exact host ("cdn.example.com") or a "*."-prefixed
+// suffix wildcard ("*.example.com", which also matches "example.com"
+// itself). Host rules take precedence over scheme rules but not over
+// extension rules.
+func (r *Registry) RegisterHost(pattern string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hosts = append(r.hosts, hostRule{pattern: strings.ToLower(pattern), backend: backend})
+}
+
+// RegisterExtension routes http(s) URLs whose path ends in ext (e.g. ".mp4"
+// or "mp4") to backend. Extension rules take precedence over both host and
+// scheme rules, since a direct file link is unambiguous regardless of which
+// host serves it.
+func (r *Registry) RegisterExtension(ext string, backend Backend) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	r.extensions[ext] = backend
+}
+
+// Lookup returns the Backend that should handle rawURL: the most specific
+// of a matching file-extension, host, or scheme rule, or the fallback
+// Backend if none matches or rawURL fails to parse.
+func (r *Registry) Lookup(rawURL string) Backend {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return r.fallback
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+
+	if scheme == "http" || scheme == "https" {
+		if backend, ok := r.extensions[strings.ToLower(path.Ext(parsed.Path))]; ok {
+			return backend
+		}
+
+		host := strings.ToLower(parsed.Hostname())
+		for _, rule := range r.hosts {
+			if hostMatches(rule.pattern, host) {
+				return rule.backend
+			}
+		}
+	}
+
+	if backend, ok := r.schemes[scheme]; ok {
+		return backend
+	}
+
+	return r.fallback
+}
+
+func hostMatches(pattern, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return host == suffix || strings.HasSuffix(host, "."+suffix)
+	}
+
+	return host == pattern
+}
+
+// Client is the scheme-dispatching entry point: it inspects a URL, picks a
+// Backend via its Registry, and falls through to yt-dlp for anything not
+// natively handled.
+type Client struct {
+	registry *Registry
+}
+
+// NewClient creates a Client backed by ytdlp as the fallback Backend. FTP
+// URLs dispatch to FTPBackend; direct-file http(s) URLs (matching
+// directFileExtensions) dispatch to HTTPBackend. Everything else, including
+// ordinary https video-site pages, still falls through to yt-dlp.
+func NewClient(ytdlp *Downloader) *Client {
+	registry := NewRegistry(ytdlp)
+	registry.RegisterScheme("ftp", &FTPBackend{})
+
+	httpBackend := &HTTPBackend{}
+	for ext := range directFileExtensions {
+		registry.RegisterExtension(ext, httpBackend)
+	}
+
+	return &Client{registry: registry}
+}
+
+// NewClientWithRegistry creates a Client backed by a caller-constructed
+// Registry, for callers that want full control over scheme/host/extension
+// dispatch or custom Backends.
+func NewClientWithRegistry(registry *Registry) *Client {
+	return &Client{registry: registry}
+}
+
+// RegisterScheme adds or replaces the Backend used for the given URL scheme.
+func (c *Client) RegisterScheme(scheme string, backend Backend) {
+	c.registry.RegisterScheme(scheme, backend)
+}
+
+// RegisterHost adds the Backend used for http(s) URLs matching pattern.
+func (c *Client) RegisterHost(pattern string, backend Backend) {
+	c.registry.RegisterHost(pattern, backend)
+}
+
+// RegisterExtension adds or replaces the Backend used for http(s) URLs
+// ending in ext.
+func (c *Client) RegisterExtension(ext string, backend Backend) {
+	c.registry.RegisterExtension(ext, backend)
+}
+
+// Download picks a Backend for url and runs the download through it.
+func (c *Client) Download(ctx context.Context, url string, opts Options) (*Result, error) {
+	backend := c.registry.Lookup(url)
+	if backend == nil {
+		return nil, errNoBackendForURL
+	}
+
+	return backend.Download(ctx, url, opts)
+}
+
+// Probe picks a Backend for url and runs metadata probing through it.
+func (c *Client) Probe(ctx context.Context, url string, opts ProbeOptions) (*VideoInfo, error) {
+	backend := c.registry.Lookup(url)
+	if backend == nil {
+		return nil, errNoBackendForURL
+	}
+
+	return backend.Probe(ctx, url, opts)
+}
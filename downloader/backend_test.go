@@ -0,0 +1,81 @@
+package downloader
+
+import (
+	"context"
+	"testing"
+)
+
+type stubBackend struct {
+	name string
+}
+
+func (s *stubBackend) Download(context.Context, string, Options) (*Result, error) {
+	return nil, nil
+}
+
+func (s *stubBackend) Probe(context.Context, string, ProbeOptions) (*VideoInfo, error) {
+	return nil, nil
+}
+
+func TestRegistryLookup(t *testing.T) {
+	fallback := &stubBackend{name: "fallback"}
+	ext := &stubBackend{name: "ext"}
+	host := &stubBackend{name: "host"}
+	ftp := &stubBackend{name: "ftp"}
+
+	registry := NewRegistry(fallback)
+	registry.RegisterExtension(".mp4", ext)
+	registry.RegisterHost("*.cdn.example.com", host)
+	registry.RegisterScheme("ftp", ftp)
+
+	tests := []struct {
+		name string
+		url  string
+		want *stubBackend
+	}{
+		{
+			name: "https direct file by extension wins over fallback",
+			url:  "https://video-site.example/watch/video.mp4",
+			want: ext,
+		},
+		{
+			name: "https host rule matches when no extension rule does",
+			url:  "https://assets.cdn.example.com/clip.bin",
+			want: host,
+		},
+		{
+			name: "https page with neither rule falls through to yt-dlp",
+			url:  "https://video-site.example/watch?v=abc123",
+			want: fallback,
+		},
+		{
+			name: "ftp extension match is not consulted for non-http(s) schemes",
+			url:  "ftp://files.example/movie.mp4",
+			want: ftp,
+		},
+		{
+			name: "ftp host rule is not consulted for non-http(s) schemes",
+			url:  "ftp://assets.cdn.example.com/movie.mp4",
+			want: ftp,
+		},
+		{
+			name: "scheme with no rule falls through to fallback",
+			url:  "magnet:?xt=urn:btih:abc123",
+			want: fallback,
+		},
+		{
+			name: "unparseable url falls through to fallback",
+			url:  "://not a url",
+			want: fallback,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := registry.Lookup(tt.url)
+			if got != tt.want {
+				t.Fatalf("Lookup(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
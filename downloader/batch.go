@@ -0,0 +1,117 @@
+package downloader
+
+import (
+	"context"
+	"sync"
+)
+
+// Job describes a single download to run as part of a batch.
+type Job struct {
+	ID      string // caller-chosen identifier; defaults to the URL if empty
+	URL     string
+	Options Options
+}
+
+// BatchOptions control how a batch of Jobs is executed.
+type BatchOptions struct {
+	Workers  int  // number of concurrent downloads; defaults to 1
+	FailFast bool // cancel remaining jobs on the first error
+
+	// Progress, if set, receives every ProgressUpdate tagged with the Job ID
+	// it belongs to. Options.Progress on individual Jobs is still honored
+	// and is called in addition to this callback.
+	Progress func(jobID string, update ProgressUpdate)
+
+	// OnJobDone, if set, is called once a Job finishes, successfully or not.
+	// TerminalPool.MarkDone has this signature and can be used directly.
+	OnJobDone func(jobID string, err error)
+}
+
+// JobResult carries the outcome of a single Job within a batch.
+type JobResult struct {
+	JobID  string
+	URL    string
+	Result *Result
+	Err    error
+}
+
+// DownloadMany runs the given Jobs concurrently, honoring BatchOptions.Workers
+// as the maximum parallelism. It returns one JobResult per Job, in the same
+// order the Jobs were given. Cancelling ctx terminates all in-flight yt-dlp
+// processes; if BatchOptions.FailFast is set, the first job error cancels the
+// remaining jobs as well.
+func (d *Downloader) DownloadMany(ctx context.Context, jobs []Job, batchOpts BatchOptions) ([]JobResult, error) {
+	workers := batchOpts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	results := make([]JobResult, len(jobs))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+	)
+
+	jobIndexes := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range jobIndexes {
+				results[idx] = d.runBatchJob(runCtx, jobs[idx], batchOpts)
+
+				if results[idx].Err != nil && batchOpts.FailFast {
+					failOnce.Do(cancel)
+				}
+			}
+		}()
+	}
+
+	for idx := range jobs {
+		jobIndexes <- idx
+	}
+	close(jobIndexes)
+
+	wg.Wait()
+
+	return results, nil
+}
+
+func (d *Downloader) runBatchJob(ctx context.Context, job Job, batchOpts BatchOptions) JobResult {
+	jobID := job.ID
+	if jobID == "" {
+		jobID = job.URL
+	}
+
+	opts := job.Options
+	if batchOpts.Progress != nil {
+		userProgress := opts.Progress
+		opts.Progress = func(update ProgressUpdate) {
+			if userProgress != nil {
+				userProgress(update)
+			}
+
+			batchOpts.Progress(jobID, update)
+		}
+	}
+
+	res, err := d.Download(ctx, job.URL, opts)
+
+	if batchOpts.OnJobDone != nil {
+		batchOpts.OnJobDone(jobID, err)
+	}
+
+	return JobResult{
+		JobID:  jobID,
+		URL:    job.URL,
+		Result: res,
+		Err:    err,
+	}
+}
@@ -0,0 +1,85 @@
+package downloader
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// TerminalPool renders one progress line per active job plus a trailing
+// total line, similar in spirit to cheggaaa/pb's multi-bar pool but without
+// any external dependency. It is safe to pass as BatchOptions.Progress via
+// TerminalPool.Update, and to track job completion via MarkDone.
+type TerminalPool struct {
+	out io.Writer
+
+	mu        sync.Mutex
+	bars      map[string]ProgressUpdate
+	done      int
+	failed    int
+	total     int
+	lastLines int
+}
+
+// NewTerminalPool creates a TerminalPool that renders to out. total is the
+// number of jobs in the batch, used for the trailing summary line.
+func NewTerminalPool(out io.Writer, total int) *TerminalPool {
+	return &TerminalPool{
+		out:   out,
+		bars:  make(map[string]ProgressUpdate),
+		total: total,
+	}
+}
+
+// Update records the latest ProgressUpdate for jobID and redraws the pool.
+// It is intended to be used directly as BatchOptions.Progress.
+func (p *TerminalPool) Update(jobID string, update ProgressUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bars[jobID] = update
+	p.render()
+}
+
+// MarkDone records that jobID finished, optionally with an error, removes
+// its bar, and redraws the pool.
+func (p *TerminalPool) MarkDone(jobID string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.bars, jobID)
+	p.done++
+
+	if err != nil {
+		p.failed++
+	}
+
+	p.render()
+}
+
+// render redraws the pool in place, overwriting the lines it previously
+// printed. Callers must hold p.mu.
+func (p *TerminalPool) render() {
+	ids := make([]string, 0, len(p.bars))
+	for id := range p.bars {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for i := 0; i < p.lastLines; i++ {
+		fmt.Fprint(p.out, "\x1b[1A\x1b[2K")
+	}
+
+	for _, id := range ids {
+		bar := p.bars[id]
+		fmt.Fprintf(p.out, "%-24s %6.2f%% | ETA %s | %s\n", id, bar.Percent, bar.ETA, bar.Speed)
+	}
+
+	inFlight := len(ids)
+	fmt.Fprintf(p.out, "total: %d done, %d failed, %d in-flight, %d/%d\n",
+		p.done, p.failed, inFlight, p.done, p.total)
+
+	p.lastLines = len(ids) + 1
+}
@@ -61,12 +61,22 @@ type Options struct {
 	Stdout         io.Writer         // tee stdout to this writer
 	Stderr         io.Writer         // tee stderr to this writer
 	Progress       func(ProgressUpdate)
+	Verify         *VerifySpec  // if set, verify produced file(s) against a checksum after download
+	Resume         bool         // true passes --continue; false (the zero value) passes --no-continue
+	PartFileDir    string       // scratch directory for in-progress .part files
+	HTTP           *HTTPOptions // options specific to HTTPBackend
+	FTP            *FTPOptions  // options specific to FTPBackend
+
+	PostProcess          []PostProcessor // run, in order, against the produced files after a successful download
+	KeepLocalAfterUpload bool            // if false, files are deleted once every PostProcessor succeeds
 }
 
 // Result carries captured output from a yt-dlp run.
 type Result struct {
-	Stdout []byte
-	Stderr []byte
+	Stdout      []byte
+	Stderr      []byte
+	Files       []DownloadedFile
+	PostProcess []ProcessorResult
 }
 
 // ProgressUpdate represents a single update emitted by yt-dlp during download.
@@ -95,6 +105,7 @@ func (d *Downloader) Download(ctx context.Context, url string, opts Options) (*R
 	}
 
 	args := buildArgs(url, opts)
+	preResumed := hadExistingPartFile(opts)
 
 	//nolint:gosec // command and args are controlled by caller for yt-dlp
 	cmd := exec.CommandContext(ctx, d.binPath, args...)
@@ -116,12 +127,24 @@ func (d *Downloader) Download(ctx context.Context, url string, opts Options) (*R
 
 	if err != nil {
 		if ctxErr := ctx.Err(); ctxErr != nil {
-			return result, fmt.Errorf("context done: %w", ctxErr)
+			return result, interruptedError(ctxErr, opts)
 		}
 
 		return result, fmt.Errorf("yt-dlp failed: %w\n%s", err, bytes.TrimSpace(result.Stderr))
 	}
 
+	result.Files = parseDownloadedFiles(result.Stdout, opts.WorkDir, preResumed)
+
+	if opts.Verify != nil {
+		if err := verifyResult(result, opts.Verify, opts.WorkDir); err != nil {
+			return result, err
+		}
+	}
+
+	if err := runPostProcessors(ctx, result, opts); err != nil {
+		return result, err
+	}
+
 	return result, nil
 }
 
@@ -129,7 +152,10 @@ func buildArgs(url string, opts Options) []string {
 	args := []string{"--newline"}
 
 	if opts.Progress != nil {
-		args = append(args, "--progress-template", progressTemplate)
+		// --print (added below for Result.Files) implies --quiet, which would
+		// otherwise suppress the progress lines this parses; --progress forces
+		// the progress meter back on regardless of --quiet.
+		args = append(args, "--progress", "--progress-template", progressTemplate)
 	} else {
 		args = append(args, "--no-progress")
 	}
@@ -168,6 +194,18 @@ func buildArgs(url string, opts Options) []string {
 		}
 	}
 
+	if opts.Resume {
+		args = append(args, "--continue")
+	} else {
+		args = append(args, "--no-continue")
+	}
+
+	if opts.PartFileDir != "" {
+		args = append(args, "--paths", "temp:"+opts.PartFileDir)
+	}
+
+	args = append(args, "--print", filePrintTemplate)
+
 	args = append(args, opts.ExtraArgs...)
 	args = append(args, url)
 
@@ -0,0 +1,55 @@
+package downloader
+
+import "testing"
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantOK      bool
+		wantPercent float64
+		wantETA     string
+		wantSpeed   string
+	}{
+		{
+			name:        "well-formed line",
+			line:        "45.5%|00:12|1.20MiB/s",
+			wantOK:      true,
+			wantPercent: 45.5,
+			wantETA:     "00:12",
+			wantSpeed:   "1.20MiB/s",
+		},
+		{
+			name:   "not-available percent",
+			line:   "N/A|Unknown|Unknown",
+			wantOK: false,
+		},
+		{
+			name:   "wrong number of parts",
+			line:   "45.5%|00:12",
+			wantOK: false,
+		},
+		{
+			name:   "non-numeric percent",
+			line:   "oops%|00:12|1.20MiB/s",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upd, ok := parseProgress(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parseProgress(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+
+			if !ok {
+				return
+			}
+
+			if upd.Percent != tt.wantPercent || upd.ETA != tt.wantETA || upd.Speed != tt.wantSpeed {
+				t.Fatalf("parseProgress(%q) = %+v, want {%v %v %v}", tt.line, upd, tt.wantPercent, tt.wantETA, tt.wantSpeed)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package downloader
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FTPOptions configures an FTPBackend download.
+type FTPOptions struct {
+	User     string // defaults to "anonymous"
+	Password string // defaults to "anonymous"
+}
+
+// FTPBackend downloads a single file from an FTP server using a plain
+// RETR over a passive-mode data connection.
+//
+// Deliberate deviation from jlaffaye/ftp: this repo ships no go.mod, so
+// there is no module manifest to pin and vendor a third-party dependency
+// against, and adding one unilaterally is a bigger call than this change
+// should make on its own. ftpConn is therefore a minimal, stdlib-only
+// control-connection client covering just the commands FTPBackend needs
+// (USER/PASS/TYPE/PASV/REST/RETR). This trades a battle-tested client for
+// ~150 lines of hand-written protocol code, of which only the reply and
+// PASV parsing have unit tests.
+type FTPBackend struct{}
+
+// Download connects to the FTP server in rawURL, authenticates, and RETRs
+// the path into Options.OutputTemplate (used verbatim), or the URL's base
+// name under Options.WorkDir if OutputTemplate is empty.
+func (b *FTPBackend) Download(ctx context.Context, rawURL string, opts Options) (*Result, error) {
+	if rawURL == "" {
+		return nil, errURLRequired
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+
+	conn, err := ftpDial(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	user, pass := "anonymous", "anonymous"
+	if opts.FTP != nil {
+		if opts.FTP.User != "" {
+			user = opts.FTP.User
+		}
+
+		if opts.FTP.Password != "" {
+			pass = opts.FTP.Password
+		}
+	}
+
+	if err := conn.login(user, pass); err != nil {
+		return nil, err
+	}
+
+	dest := ftpDestination(parsed, opts)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return nil, fmt.Errorf("create destination dir: %w", err)
+	}
+
+	var startAt int64
+
+	if opts.Resume {
+		if info, statErr := os.Stat(dest); statErr == nil {
+			startAt = info.Size()
+		}
+	}
+
+	dataConn, err := conn.passive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if startAt > 0 {
+		if err := conn.send(fmt.Sprintf("REST %d", startAt)); err != nil {
+			dataConn.Close()
+			return nil, err
+		}
+
+		if _, err := conn.readResponse(); err != nil {
+			// Server doesn't support REST at this offset; restart from scratch.
+			startAt = 0
+		}
+	}
+
+	if err := conn.send("RETR " + parsed.Path); err != nil {
+		dataConn.Close()
+		return nil, err
+	}
+
+	if _, err := conn.readResponse(); err != nil {
+		dataConn.Close()
+		return nil, err
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if startAt > 0 {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	out, err := os.OpenFile(dest, flags, 0o644) //nolint:gosec // destination resolved from caller options/URL
+	if err != nil {
+		dataConn.Close()
+		return nil, fmt.Errorf("open destination: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := copyWithProgress(ctx, out, dataConn, opts.Progress, startAt, 0); err != nil {
+		dataConn.Close()
+		return nil, fmt.Errorf("ftp retr %s: %w", rawURL, err)
+	}
+
+	dataConn.Close()
+
+	if _, err := conn.readResponse(); err != nil {
+		return nil, err
+	}
+
+	info, err := out.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat destination: %w", err)
+	}
+
+	result := &Result{
+		Files: []DownloadedFile{{Path: dest, Bytes: info.Size(), Resumed: startAt > 0}},
+	}
+
+	if opts.Verify != nil {
+		if err := verifyResult(result, opts.Verify, ""); err != nil {
+			return result, err
+		}
+	}
+
+	if err := runPostProcessors(ctx, result, opts); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Probe is not supported for plain FTP downloads.
+func (b *FTPBackend) Probe(_ context.Context, _ string, _ ProbeOptions) (*VideoInfo, error) {
+	return nil, errProbeUnsupported
+}
+
+func ftpDestination(parsed *url.URL, opts Options) string {
+	if opts.OutputTemplate != "" {
+		if opts.WorkDir != "" && !filepath.IsAbs(opts.OutputTemplate) {
+			return filepath.Join(opts.WorkDir, opts.OutputTemplate)
+		}
+
+		return opts.OutputTemplate
+	}
+
+	name := path.Base(parsed.Path)
+	if name == "" || name == "/" || name == "." {
+		name = "download"
+	}
+
+	if opts.WorkDir != "" {
+		return filepath.Join(opts.WorkDir, name)
+	}
+
+	return name
+}
+
+// ftpConn is a minimal FTP control-connection client supporting the small
+// command set needed for an anonymous/basic-auth, resumable RETR: USER,
+// PASS, TYPE, PASV, REST, and RETR.
+type ftpConn struct {
+	ctrl net.Conn
+	r    *bufio.Reader
+}
+
+func ftpDial(ctx context.Context, parsed *url.URL) (*ftpConn, error) {
+	host := parsed.Host
+	if parsed.Port() == "" {
+		host = net.JoinHostPort(parsed.Hostname(), "21")
+	}
+
+	var d net.Dialer
+
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", host, err)
+	}
+
+	c := &ftpConn{ctrl: conn, r: bufio.NewReader(conn)}
+
+	if _, err := c.readResponse(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *ftpConn) Close() error {
+	return c.ctrl.Close()
+}
+
+func (c *ftpConn) send(line string) error {
+	_, err := c.ctrl.Write([]byte(line + "\r\n"))
+	if err != nil {
+		return fmt.Errorf("ftp send %q: %w", line, err)
+	}
+
+	return nil
+}
+
+// readResponse reads a single FTP reply, which per RFC 959 may span
+// multiple lines: a line "CODE-text" opens a multiline reply that only
+// ends at a later line starting with the same "CODE " (note the space).
+// Reading just the first line would desync the control connection against
+// any server that sends multiline banners/greetings.
+func (c *ftpConn) readResponse() (string, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 4 || (line[0] != '1' && line[0] != '2' && line[0] != '3') {
+		return line, fmt.Errorf("ftp error response: %s", line)
+	}
+
+	code := line[:3]
+
+	if line[3] == '-' {
+		for {
+			next, err := c.readLine()
+			if err != nil {
+				return "", err
+			}
+
+			line = next
+
+			if strings.HasPrefix(line, code+" ") {
+				break
+			}
+		}
+	}
+
+	return line, nil
+}
+
+func (c *ftpConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("ftp read response: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *ftpConn) login(user, pass string) error {
+	if err := c.send("USER " + user); err != nil {
+		return err
+	}
+
+	if _, err := c.readResponse(); err != nil {
+		return err
+	}
+
+	if err := c.send("PASS " + pass); err != nil {
+		return err
+	}
+
+	if _, err := c.readResponse(); err != nil {
+		return err
+	}
+
+	if err := c.send("TYPE I"); err != nil {
+		return err
+	}
+
+	_, err := c.readResponse()
+
+	return err
+}
+
+var ftpPasvRE = strings.NewReplacer("(", "", ")", "")
+
+func (c *ftpConn) passive(ctx context.Context) (net.Conn, error) {
+	if err := c.send("PASV"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.readResponse()
+	if err != nil {
+		return nil, err
+	}
+
+	host, port, err := parsePasvResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+
+	dataConn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("ftp data dial: %w", err)
+	}
+
+	return dataConn, nil
+}
+
+// parsePasvResponse extracts the data-connection host and port from a PASV
+// reply of the form "227 Entering Passive Mode (h1,h2,h3,h4,p1,p2).", per
+// RFC 1123 4.1.2.6.
+func parsePasvResponse(resp string) (string, int, error) {
+	open := strings.IndexByte(resp, '(')
+	closeIdx := strings.IndexByte(resp, ')')
+
+	if open < 0 || closeIdx < 0 || closeIdx < open {
+		return "", 0, fmt.Errorf("ftp pasv: unexpected response %q", resp)
+	}
+
+	parts := strings.Split(ftpPasvRE.Replace(resp[open:closeIdx+1]), ",")
+	if len(parts) != 6 {
+		return "", 0, fmt.Errorf("ftp pasv: unexpected response %q", resp)
+	}
+
+	p1, err1 := strconv.Atoi(parts[4])
+	p2, err2 := strconv.Atoi(parts[5])
+
+	if err1 != nil || err2 != nil {
+		return "", 0, fmt.Errorf("ftp pasv: unexpected response %q", resp)
+	}
+
+	return strings.Join(parts[0:4], "."), p1*256 + p2, nil
+}
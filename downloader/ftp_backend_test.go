@@ -0,0 +1,114 @@
+package downloader
+
+import (
+	"bufio"
+	"net"
+	"testing"
+)
+
+func TestParsePasvResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     string
+		wantHost string
+		wantPort int
+		wantErr  bool
+	}{
+		{
+			name:     "standard reply",
+			resp:     "227 Entering Passive Mode (192,168,1,1,200,13).",
+			wantHost: "192.168.1.1",
+			wantPort: 200*256 + 13,
+		},
+		{
+			name:    "missing parens",
+			resp:    "227 Entering Passive Mode 192,168,1,1,200,13",
+			wantErr: true,
+		},
+		{
+			name:    "wrong field count",
+			resp:    "227 Entering Passive Mode (192,168,1,1,200).",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric port fields",
+			resp:    "227 Entering Passive Mode (192,168,1,1,a,b).",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port, err := parsePasvResponse(tt.resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePasvResponse(%q) error = %v, wantErr %v", tt.resp, err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if host != tt.wantHost || port != tt.wantPort {
+				t.Fatalf("parsePasvResponse(%q) = (%q, %d), want (%q, %d)", tt.resp, host, port, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestFtpConnReadResponseMultiline(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	conn := &ftpConn{ctrl: client, r: bufio.NewReader(client)}
+
+	go func() {
+		_, _ = server.Write([]byte("230-Welcome to the server\r\n230-Line two of the banner\r\n230 Logged in\r\n"))
+	}()
+
+	resp, err := conn.readResponse()
+	if err != nil {
+		t.Fatalf("readResponse() error = %v", err)
+	}
+
+	if resp != "230 Logged in" {
+		t.Fatalf("readResponse() = %q, want %q", resp, "230 Logged in")
+	}
+}
+
+func TestFtpConnReadResponseSingleLine(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	conn := &ftpConn{ctrl: client, r: bufio.NewReader(client)}
+
+	go func() {
+		_, _ = server.Write([]byte("200 Command okay\r\n"))
+	}()
+
+	resp, err := conn.readResponse()
+	if err != nil {
+		t.Fatalf("readResponse() error = %v", err)
+	}
+
+	if resp != "200 Command okay" {
+		t.Fatalf("readResponse() = %q, want %q", resp, "200 Command okay")
+	}
+}
+
+func TestFtpConnReadResponseError(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	conn := &ftpConn{ctrl: client, r: bufio.NewReader(client)}
+
+	go func() {
+		_, _ = server.Write([]byte("550 File not found\r\n"))
+	}()
+
+	if _, err := conn.readResponse(); err == nil {
+		t.Fatal("readResponse() error = nil, want non-nil for a 5xx reply")
+	}
+}
@@ -0,0 +1,108 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+)
+
+var errRcloneNotConfigured = errors.New("rclone binary is not configured")
+
+// PostProcessor runs after a successful download, given the files it
+// produced. Processors run sequentially in the order given in
+// Options.PostProcess.
+type PostProcessor interface {
+	Name() string
+	Process(ctx context.Context, files []DownloadedFile) (ProcessorResult, error)
+}
+
+// ProcessorResult carries the captured output of a single PostProcessor run.
+type ProcessorResult struct {
+	Name   string
+	Stdout []byte
+	Stderr []byte
+	Err    error
+}
+
+// runPostProcessors executes opts.PostProcess in order against result.Files,
+// appending one ProcessorResult per processor to result.PostProcess. Unless
+// opts.KeepLocalAfterUpload is set, files are deleted once every processor
+// has succeeded; if any processor fails, the local files are always kept so
+// the upload can be retried.
+func runPostProcessors(ctx context.Context, result *Result, opts Options) error {
+	if len(opts.PostProcess) == 0 || len(result.Files) == 0 {
+		return nil
+	}
+
+	var firstErr error
+
+	for _, proc := range opts.PostProcess {
+		procResult, err := proc.Process(ctx, result.Files)
+		procResult.Name = proc.Name()
+		procResult.Err = err
+
+		result.PostProcess = append(result.PostProcess, procResult)
+
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("post-process %s: %w", proc.Name(), err)
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if !opts.KeepLocalAfterUpload {
+		for _, f := range result.Files {
+			_ = os.Remove(f.Path)
+		}
+	}
+
+	return nil
+}
+
+// RcloneCopy is a PostProcessor that uploads each produced file to a remote
+// configured for the rclone binary, whose path is set explicitly in BinPath
+// just as New's binaryPath sets the yt-dlp binary elsewhere in this package.
+type RcloneCopy struct {
+	BinPath string   // path to the rclone executable
+	Remote  string   // configured rclone remote name, e.g. "myremote"
+	Path    string   // destination path/prefix on the remote
+	Flags   []string // extra flags passed to `rclone copyto`
+}
+
+// Name identifies this processor in ProcessorResult.Name.
+func (r *RcloneCopy) Name() string {
+	return "rclone:" + r.Remote
+}
+
+// Process uploads each file to Remote:Path/<basename> via `rclone copyto`.
+func (r *RcloneCopy) Process(ctx context.Context, files []DownloadedFile) (ProcessorResult, error) {
+	if r.BinPath == "" {
+		return ProcessorResult{}, errRcloneNotConfigured
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	for _, f := range files {
+		dest := r.Remote + ":" + path.Join(r.Path, filepath.Base(f.Path))
+		args := append([]string{"copyto", f.Path, dest}, r.Flags...)
+
+		//nolint:gosec // command and args are controlled by caller for rclone
+		cmd := exec.CommandContext(ctx, r.BinPath, args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return ProcessorResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()},
+				fmt.Errorf("rclone copyto %s: %w\n%s", dest, err, bytes.TrimSpace(stderr.Bytes()))
+		}
+	}
+
+	return ProcessorResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}, nil
+}
@@ -0,0 +1,203 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// VideoInfo is the parsed metadata yt-dlp reports for a single video. When
+// Probe is called against a playlist URL with ProbeOptions.Playlist or
+// FlatPlaylist set, yt-dlp instead returns a playlist object: Formats is
+// empty and the per-video data lives in Entries.
+type VideoInfo struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Uploader    string      `json:"uploader"`
+	Description string      `json:"description"`
+	Duration    float64     `json:"duration"`
+	Thumbnail   string      `json:"thumbnail"`
+	Formats     []Format    `json:"formats"`
+	Entries     []VideoInfo `json:"entries,omitempty"`
+}
+
+// Format describes a single downloadable stream reported by yt-dlp.
+type Format struct {
+	FormatID   string  `json:"format_id"`
+	Ext        string  `json:"ext"`
+	Resolution string  `json:"resolution"`
+	FPS        float64 `json:"fps"`
+	VCodec     string  `json:"vcodec"`
+	ACodec     string  `json:"acodec"`
+	TBR        float64 `json:"tbr"`
+	Filesize   int64   `json:"filesize"`
+	Protocol   string  `json:"protocol"`
+	Height     int     `json:"height"`
+	Width      int     `json:"width"`
+}
+
+// VideoOnly reports whether the format carries no audio stream.
+func (f Format) VideoOnly() bool {
+	return f.ACodec == "" || f.ACodec == "none"
+}
+
+// AudioOnly reports whether the format carries no video stream.
+func (f Format) AudioOnly() bool {
+	return f.VCodec == "" || f.VCodec == "none"
+}
+
+// ProbeOptions control a single metadata-only yt-dlp invocation.
+type ProbeOptions struct {
+	Playlist     bool              // if false and FlatPlaylist is not set, passes --no-playlist
+	FlatPlaylist bool              // if true, passes --flat-playlist instead of dumping full info
+	Proxy        string            // e.g. "socks5://127.0.0.1:9050"
+	CookiesFile  string            // path to a Netscape cookies.txt file
+	Headers      map[string]string // extra headers to send
+	ExtraArgs    []string          // raw args passed to yt-dlp before the URL
+	WorkDir      string            // optional working directory for the command
+}
+
+// Probe runs yt-dlp in metadata-only mode and decodes the resulting JSON
+// document into a VideoInfo, without downloading any media.
+func (d *Downloader) Probe(ctx context.Context, url string, opts ProbeOptions) (*VideoInfo, error) {
+	if url == "" {
+		return nil, errURLRequired
+	}
+
+	if d.binPath == "" {
+		return nil, errBinaryNotConfigured
+	}
+
+	args := buildProbeArgs(url, opts)
+
+	//nolint:gosec // command and args are controlled by caller for yt-dlp
+	cmd := exec.CommandContext(ctx, d.binPath, args...)
+	if opts.WorkDir != "" {
+		cmd.Dir = opts.WorkDir
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("context done: %w", ctxErr)
+		}
+
+		return nil, fmt.Errorf("yt-dlp probe failed: %w\n%s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	info := &VideoInfo{}
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), info); err != nil {
+		return nil, fmt.Errorf("decode video info: %w", err)
+	}
+
+	return info, nil
+}
+
+func buildProbeArgs(url string, opts ProbeOptions) []string {
+	args := []string{"--no-progress", "--dump-single-json"}
+
+	if opts.FlatPlaylist {
+		args = append(args, "--flat-playlist")
+	} else if !opts.Playlist {
+		args = append(args, "--no-playlist")
+	}
+
+	if opts.Proxy != "" {
+		args = append(args, "--proxy", opts.Proxy)
+	}
+
+	if opts.CookiesFile != "" {
+		args = append(args, "--cookies", opts.CookiesFile)
+	}
+
+	if len(opts.Headers) > 0 {
+		keys := make([]string, 0, len(opts.Headers))
+		for headerKey := range opts.Headers {
+			keys = append(keys, headerKey)
+		}
+
+		sort.Strings(keys)
+
+		for _, headerKey := range keys {
+			v := strings.TrimSpace(opts.Headers[headerKey])
+			if v == "" {
+				continue
+			}
+
+			args = append(args, "--add-header", fmt.Sprintf("%s:%s", headerKey, v))
+		}
+	}
+
+	args = append(args, opts.ExtraArgs...)
+	args = append(args, url)
+
+	return args
+}
+
+// FormatFilter narrows down a list of Formats before selecting one with
+// SelectFormat. Zero values mean "no constraint".
+type FormatFilter struct {
+	Ext       string // e.g. "mp4"
+	MinHeight int
+	MaxHeight int
+	VCodec    string // substring match, e.g. "avc1"
+	ACodec    string // substring match, e.g. "mp4a"
+	VideoOnly bool
+	AudioOnly bool
+}
+
+// SelectFormat filters formats by the given FormatFilter and returns the
+// best match sorted by TBR (total bitrate) descending. It returns false if
+// no format satisfies the filter.
+func SelectFormat(formats []Format, filter FormatFilter) (Format, bool) {
+	candidates := make([]Format, 0, len(formats))
+
+	for _, f := range formats {
+		if filter.Ext != "" && !strings.EqualFold(f.Ext, filter.Ext) {
+			continue
+		}
+
+		if filter.MinHeight > 0 && f.Height < filter.MinHeight {
+			continue
+		}
+
+		if filter.MaxHeight > 0 && f.Height > filter.MaxHeight {
+			continue
+		}
+
+		if filter.VCodec != "" && !strings.Contains(f.VCodec, filter.VCodec) {
+			continue
+		}
+
+		if filter.ACodec != "" && !strings.Contains(f.ACodec, filter.ACodec) {
+			continue
+		}
+
+		if filter.VideoOnly && !f.VideoOnly() {
+			continue
+		}
+
+		if filter.AudioOnly && !f.AudioOnly() {
+			continue
+		}
+
+		candidates = append(candidates, f)
+	}
+
+	if len(candidates) == 0 {
+		return Format{}, false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].TBR > candidates[j].TBR
+	})
+
+	return candidates[0], true
+}
@@ -0,0 +1,75 @@
+package downloader
+
+import "testing"
+
+func TestSelectFormat(t *testing.T) {
+	formats := []Format{
+		{FormatID: "1", Ext: "webm", Height: 480, TBR: 500, VCodec: "vp9", ACodec: "opus"},
+		{FormatID: "2", Ext: "mp4", Height: 720, TBR: 1000, VCodec: "avc1", ACodec: "mp4a"},
+		{FormatID: "3", Ext: "mp4", Height: 1080, TBR: 2000, VCodec: "avc1", ACodec: "mp4a"},
+		{FormatID: "4", Ext: "mp4", Height: 1080, TBR: 1500, VCodec: "avc1", ACodec: "none"},
+		{FormatID: "5", Ext: "m4a", Height: 0, TBR: 128, VCodec: "none", ACodec: "mp4a"},
+	}
+
+	tests := []struct {
+		name   string
+		filter FormatFilter
+		wantID string
+		wantOK bool
+	}{
+		{
+			name:   "highest bitrate with no filter",
+			filter: FormatFilter{},
+			wantID: "3",
+			wantOK: true,
+		},
+		{
+			name:   "by extension",
+			filter: FormatFilter{Ext: "webm"},
+			wantID: "1",
+			wantOK: true,
+		},
+		{
+			name:   "min height excludes lower resolutions",
+			filter: FormatFilter{MinHeight: 1000},
+			wantID: "3",
+			wantOK: true,
+		},
+		{
+			name:   "max height excludes higher resolutions",
+			filter: FormatFilter{MaxHeight: 720},
+			wantID: "2",
+			wantOK: true,
+		},
+		{
+			name:   "video only",
+			filter: FormatFilter{VideoOnly: true},
+			wantID: "4",
+			wantOK: true,
+		},
+		{
+			name:   "audio only",
+			filter: FormatFilter{AudioOnly: true},
+			wantID: "5",
+			wantOK: true,
+		},
+		{
+			name:   "no match",
+			filter: FormatFilter{MinHeight: 4000},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SelectFormat(formats, tt.filter)
+			if ok != tt.wantOK {
+				t.Fatalf("SelectFormat() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok && got.FormatID != tt.wantID {
+				t.Fatalf("SelectFormat() = format %q, want %q", got.FormatID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package downloader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// filePathMarker prefixes the literal text yt-dlp emits around the
+// substituted %(filepath)s output-template field, so the line can be told
+// apart from yt-dlp's own bracketed status lines.
+const filePathMarker = "[file] "
+
+// filePrintTemplate is passed to yt-dlp's --print flag so that, after each
+// file is moved to its final location, yt-dlp prints a single marked line
+// with its resolved path.
+const filePrintTemplate = "after_move:" + filePathMarker + "%(filepath)s"
+
+// DownloadedFile describes a single file produced by a Download call.
+type DownloadedFile struct {
+	Path    string
+	Bytes   int64
+	Resumed bool
+}
+
+var resumingLineRE = regexp.MustCompile(`^\[download] Resuming download at byte (\d+)$`)
+
+// hadExistingPartFile reports whether a .part file was already present
+// (with a nonzero size) in opts' part-file directory before Download ran
+// yt-dlp. buildArgs always passes --print, which implies --quiet and
+// suppresses yt-dlp's "[download] Resuming download at byte N" log line, so
+// this pre-run check — not that line — is parseDownloadedFiles' primary
+// signal for DownloadedFile.Resumed.
+func hadExistingPartFile(opts Options) bool {
+	if !opts.Resume {
+		return false
+	}
+
+	dir := opts.PartFileDir
+	if dir == "" {
+		dir = opts.WorkDir
+	}
+
+	_, size := locatePartFile(dir)
+
+	return size > 0
+}
+
+// parseDownloadedFiles walks yt-dlp's stdout and builds one DownloadedFile
+// per filePathMarker line. preResumed (from hadExistingPartFile, checked
+// before yt-dlp ran) flags every returned file as Resumed; the
+// "[download] Resuming download at byte N" line is also still honored as a
+// belt-and-suspenders check for yt-dlp configurations where it isn't
+// suppressed.
+func parseDownloadedFiles(stdout []byte, workDir string, preResumed bool) []DownloadedFile {
+	var (
+		files       []DownloadedFile
+		sawResuming bool
+	)
+
+	for _, line := range strings.Split(string(stdout), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if resumingLineRE.MatchString(strings.TrimSpace(line)) {
+			sawResuming = true
+			continue
+		}
+
+		path, ok := strings.CutPrefix(strings.TrimSpace(line), filePathMarker)
+		if !ok {
+			continue
+		}
+
+		resolved := path
+		if workDir != "" && !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(workDir, resolved)
+		}
+
+		var size int64
+		if info, err := os.Stat(resolved); err == nil {
+			size = info.Size()
+		}
+
+		files = append(files, DownloadedFile{
+			Path:    resolved,
+			Bytes:   size,
+			Resumed: preResumed || sawResuming,
+		})
+
+		sawResuming = false
+	}
+
+	return files
+}
+
+// ErrInterrupted is returned by Download when ctx is cancelled mid-transfer.
+// The in-progress .part file is left on disk so a subsequent call with
+// Options.Resume set and the same OutputTemplate (and PartFileDir, if set)
+// resumes where it stopped.
+type ErrInterrupted struct {
+	Path  string // the .part file left on disk, if one could be located
+	Bytes int64  // size of the partial .part file, if known
+	Err   error  // the underlying context error
+}
+
+func (e *ErrInterrupted) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("download interrupted: %s", e.Err)
+	}
+
+	return fmt.Sprintf("download interrupted: %s (%d bytes at %s)", e.Err, e.Bytes, e.Path)
+}
+
+func (e *ErrInterrupted) Unwrap() error {
+	return e.Err
+}
+
+func interruptedError(ctxErr error, opts Options) error {
+	partDir := opts.PartFileDir
+	if partDir == "" {
+		partDir = opts.WorkDir
+	}
+
+	path, size := locatePartFile(partDir)
+
+	return &ErrInterrupted{Path: path, Bytes: size, Err: fmt.Errorf("context done: %w", ctxErr)}
+}
+
+// locatePartFile returns the most recently modified .part file in dir, if
+// any, along with its current size.
+func locatePartFile(dir string) (string, int64) {
+	if dir == "" {
+		return "", 0
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", 0
+	}
+
+	var (
+		newestPath string
+		newestSize int64
+		newestMod  int64
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".part") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if modUnix := info.ModTime().Unix(); newestPath == "" || modUnix > newestMod {
+			newestPath = filepath.Join(dir, entry.Name())
+			newestSize = info.Size()
+			newestMod = modUnix
+		}
+	}
+
+	return newestPath, newestSize
+}
@@ -0,0 +1,103 @@
+package downloader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchChecksums(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]string
+	}{
+		{
+			name: "sha256sum-style file with multiple entries",
+			body: "deadbeef  video.mp4\ncafef00d *audio.m4a\n",
+			want: map[string]string{"video.mp4": "deadbeef", "audio.m4a": "cafef00d"},
+		},
+		{
+			name: "single bare digest applies to any file",
+			body: "deadbeef\n",
+			want: map[string]string{"": "deadbeef"},
+		},
+		{
+			name: "blank lines are skipped",
+			body: "\ndeadbeef  video.mp4\n\n",
+			want: map[string]string{"video.mp4": "deadbeef"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got, err := fetchChecksums(srv.URL)
+			if err != nil {
+				t.Fatalf("fetchChecksums() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("fetchChecksums() = %v, want %v", got, tt.want)
+			}
+
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("fetchChecksums()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestExpectedChecksum(t *testing.T) {
+	tests := []struct {
+		name      string
+		checksums map[string]string
+		single    string
+		path      string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "exact filename match in map wins",
+			checksums: map[string]string{"video.mp4": "deadbeef"},
+			path:      "/tmp/video.mp4",
+			want:      "deadbeef",
+		},
+		{
+			name:      "bare digest under empty key applies to any file",
+			checksums: map[string]string{"": "deadbeef"},
+			path:      "/tmp/video.mp4",
+			want:      "deadbeef",
+		},
+		{
+			name:   "falls back to single checksum",
+			single: "deadbeef",
+			path:   "/tmp/video.mp4",
+			want:   "deadbeef",
+		},
+		{
+			name:    "no match anywhere is an error",
+			path:    "/tmp/video.mp4",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expectedChecksum(tt.checksums, tt.single, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expectedChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got != tt.want {
+				t.Fatalf("expectedChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}